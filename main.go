@@ -3,7 +3,6 @@ package main
 import (
 	"flag"
 	"log"
-	"net/http"
 
 	"github.com/dikaio/scribe-server/server"
 )
@@ -14,20 +13,45 @@ func main() {
 	dir := flag.String("dir", "./public", "Directory to serve files from")
 	noHtmlExt := flag.Bool("no-html-ext", false, "Disable .html extension auto-adding")
 	noLogging := flag.Bool("no-logging", false, "Disable request logging")
+	dev := flag.Bool("dev", false, "Enable dev mode: watch dir for changes and live-reload the browser")
+	useTLS := flag.Bool("tls", false, "Serve over HTTPS")
+	tlsCert := flag.String("tls-cert", "", "TLS certificate file (generates a self-signed dev cert if unset)")
+	tlsKey := flag.String("tls-key", "", "TLS key file (generates a self-signed dev cert if unset)")
 	flag.Parse()
 
-	// Create a server config
-	config := server.Config{
-		RootDir:          *dir,
-		AddHtmlExtension: !*noHtmlExt,
-		EnableLogging:    !*noLogging,
-	}
+	var srv *server.Server
+	var err error
+
+	if *dev {
+		// Create a dev server that watches dir and live-reloads connected browsers
+		srv, err = server.NewDevServer(*dir, nil)
+		if err != nil {
+			log.Fatalf("Failed to start dev server: %v", err)
+		}
+	} else {
+		// Create a server config
+		config := server.Config{
+			RootDir:          *dir,
+			AddHtmlExtension: !*noHtmlExt,
+			EnableLogging:    !*noLogging,
+			TLS: server.TLSConfig{
+				CertFile: *tlsCert,
+				KeyFile:  *tlsKey,
+			},
+		}
 
-	// Create a new server with config
-	srv := server.NewServerWithConfig(config)
+		// Create a new server with config
+		srv = server.NewServerWithConfig(config)
+	}
 
 	// Start the server
 	log.Printf("Starting server on port %s serving files from %s\n", *port, *dir)
 	log.Printf("HTML extension auto-adding: %v, Logging: %v\n", !*noHtmlExt, !*noLogging)
-	log.Fatal(http.ListenAndServe(":"+*port, srv))
-}
\ No newline at end of file
+
+	addr := ":" + *port
+	if *useTLS {
+		log.Fatal(srv.ListenAndServeTLS(addr))
+	} else {
+		log.Fatal(server.ListenAndServe(addr, srv))
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	pathpkg "path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// browseEntry is one row in a directory listing.
+type browseEntry struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+}
+
+// browseListing is the data passed to the browse template.
+type browseListing struct {
+	Path      string
+	Parent    string
+	HasParent bool
+	Sort      string
+	Order     string
+	Entries   []browseEntry
+}
+
+var defaultBrowseTemplate = template.Must(template.New("browse").Funcs(template.FuncMap{
+	"humanSize": humanSize,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><title>Index of {{.Path}}</title></head>
+<body>
+<h1>Index of {{.Path}}</h1>
+<ul>
+{{if .HasParent}}<li><a href="{{.Parent}}">..</a></li>{{end}}
+{{range .Entries}}<li><a href="{{.Name}}{{if .IsDir}}/{{end}}">{{.Name}}{{if .IsDir}}/{{end}}</a> - {{humanSize .Size}} - {{.ModTime.Format "2006-01-02 15:04:05"}}</li>
+{{end}}
+</ul>
+</body>
+</html>
+`))
+
+// serveBrowse renders a directory listing for dirName. It returns false if
+// dirName doesn't exist or isn't a directory, so the caller can fall back to
+// its normal 404 handling.
+func (s *Server) serveBrowse(w http.ResponseWriter, r *http.Request, dirName string) bool {
+	if dirName == "" {
+		dirName = "."
+	}
+
+	dirEntries, err := fs.ReadDir(s.fsys, dirName)
+	if err != nil {
+		return false
+	}
+
+	entries := make([]browseEntry, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		if !s.config.Browse.ShowHidden && strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, browseEntry{
+			Name:    e.Name(),
+			IsDir:   e.IsDir(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+	}
+
+	sortKey := r.URL.Query().Get("sort")
+	order := r.URL.Query().Get("order")
+	sortBrowseEntries(entries, sortKey, order)
+
+	if acceptsJSON(r) {
+		s.writeBrowseJSON(w, entries)
+		return true
+	}
+
+	listing := browseListing{
+		Path:    "/" + dirName,
+		Sort:    sortKey,
+		Order:   order,
+		Entries: entries,
+	}
+	if dirName != "." {
+		parent := pathpkg.Dir(dirName)
+		if parent == "." {
+			listing.Parent = "/"
+		} else {
+			listing.Parent = "/" + parent + "/"
+		}
+		listing.HasParent = true
+	}
+
+	tmpl := s.config.Browse.Template
+	if tmpl == nil {
+		tmpl = defaultBrowseTemplate
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, listing); err != nil {
+		s.logger.Errorf("Error rendering directory listing for %s: %v", dirName, err)
+	}
+	return true
+}
+
+// writeBrowseJSON writes the listing as JSON for tooling to consume.
+func (s *Server) writeBrowseJSON(w http.ResponseWriter, entries []browseEntry) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		s.logger.Errorf("Error encoding directory listing: %v", err)
+	}
+}
+
+// acceptsJSON reports whether the request prefers a JSON response.
+func acceptsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// sortBrowseEntries sorts entries in place by name, size, or time, ascending
+// or descending. Directories are not grouped separately; name/asc is the
+// default.
+func sortBrowseEntries(entries []browseEntry, sortKey, order string) {
+	less := func(i, j int) bool {
+		switch sortKey {
+		case "size":
+			return entries[i].Size < entries[j].Size
+		case "time":
+			return entries[i].ModTime.Before(entries[j].ModTime)
+		default:
+			return entries[i].Name < entries[j].Name
+		}
+	}
+
+	if order == "desc" {
+		base := less
+		less = func(i, j int) bool { return base(j, i) }
+	}
+
+	sort.Slice(entries, less)
+}
+
+// humanSize formats a byte count as a human-readable string (e.g. "1.5 KiB").
+func humanSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
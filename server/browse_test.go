@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newBrowseTestDir(t *testing.T) string {
+	t.Helper()
+	tempDir, err := os.MkdirTemp("", "scribe-server-browse-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	assetsDir := filepath.Join(tempDir, "assets")
+	if err := os.Mkdir(assetsDir, 0755); err != nil {
+		t.Fatalf("Failed to create assets dir: %v", err)
+	}
+
+	files := map[string]string{
+		"b.txt": "bb",
+		"a.txt": "a",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return tempDir
+}
+
+func TestBrowseRendersHTMLListing(t *testing.T) {
+	tempDir := newBrowseTestDir(t)
+
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		Browse:  BrowseConfig{Enabled: true},
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	body := w.Body.String()
+	for _, name := range []string{"a.txt", "b.txt", "assets"} {
+		if !contains(body, name) {
+			t.Errorf("Expected listing to contain %q, got: %s", name, body)
+		}
+	}
+}
+
+func TestBrowseReturnsJSONWhenRequested(t *testing.T) {
+	tempDir := newBrowseTestDir(t)
+
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		Browse:  BrowseConfig{Enabled: true},
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected application/json content type, got %q", ct)
+	}
+
+	var entries []browseEntry
+	if err := json.NewDecoder(w.Body).Decode(&entries); err != nil {
+		t.Fatalf("Failed to decode JSON listing: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+}
+
+func TestBrowseSortBySizeDescending(t *testing.T) {
+	tempDir := newBrowseTestDir(t)
+
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		Browse:  BrowseConfig{Enabled: true},
+	})
+
+	req := httptest.NewRequest("GET", "/?sort=size&order=desc", nil)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	var entries []browseEntry
+	if err := json.NewDecoder(w.Body).Decode(&entries); err != nil {
+		t.Fatalf("Failed to decode JSON listing: %v", err)
+	}
+
+	for i := 1; i < len(entries); i++ {
+		if entries[i-1].Size < entries[i].Size {
+			t.Errorf("Expected entries sorted by size descending, got: %+v", entries)
+		}
+	}
+}
@@ -1,18 +1,119 @@
 package server
 
+import (
+	"html/template"
+	"io/fs"
+	"time"
+)
+
 // Config holds server configuration options
 type Config struct {
-	// RootDir is the directory to serve files from
+	// RootDir is the directory to serve files from. Ignored when FS is set.
 	RootDir string
-	
+
+	// FS is the filesystem to serve files from. When nil, the server
+	// defaults to os.DirFS(RootDir). Set this to serve from an embed.FS
+	// (see NewEmbedFS) or a zip archive (see OpenZipFS) instead of disk.
+	FS fs.FS
+
 	// AddHtmlExtension determines whether to try adding .html extension to paths without extensions
 	AddHtmlExtension bool
-	
+
 	// EnableLogging controls whether to log requests
 	EnableLogging bool
-	
+
 	// NotFoundHandler is a custom handler for 404 errors
 	NotFoundHandler func(string) []byte
+
+	// DevMode enables live-reload: RootDir is watched for changes and HTML
+	// responses get a small script injected that reloads the page when
+	// a change is signalled. Setting this directly has no effect on its
+	// own - the watcher and SSE hub are only started by NewDevServer, which
+	// is the supported way to turn DevMode on. Set via NewServerWithConfig
+	// without going through NewDevServer, it's a harmless no-op.
+	DevMode bool
+
+	// OnChange is called whenever a watched file changes, before the
+	// reload signal is sent to connected browsers. It gives scribe a
+	// chance to regenerate the site. A nil OnChange simply skips the
+	// rebuild step.
+	OnChange func() error
+
+	// ReloadDebounce is the window used to coalesce bursts of filesystem
+	// events into a single reload. Defaults to 100ms when zero.
+	ReloadDebounce time.Duration
+
+	// ErrorPages enables serving static error pages (404.html, 500.html,
+	// error.html) for 4xx/5xx responses instead of the built-in defaults.
+	ErrorPages ErrorPagesConfig
+
+	// Browse enables directory listings for directories that have no
+	// index.html.
+	Browse BrowseConfig
+
+	// StrongETag computes ETags from a content hash instead of size+mtime.
+	// More expensive, but safe across filesystems that don't preserve mtimes.
+	StrongETag bool
+
+	// PrecompressedEncodings lists content encodings, in preference order,
+	// the server should look for a precompressed sibling file of (e.g.
+	// "about.html.br" for "br"). Supported values are "br" and "gzip".
+	PrecompressedEncodings []string
+
+	// Logger receives structured request and diagnostic events. When nil,
+	// the server falls back to a CommonLogFormatLogger (if EnableLogging)
+	// or discards everything.
+	Logger Logger
+
+	// TLS configures HTTPS serving via Server.ListenAndServeTLS.
+	TLS TLSConfig
+}
+
+// TLSConfig controls how Server.ListenAndServeTLS obtains its certificate.
+type TLSConfig struct {
+	// CertFile and KeyFile are a user-supplied certificate/key pair. When
+	// both are set, the self-signed dev cert is skipped; ACME still takes
+	// precedence when ACME.Domains is non-empty.
+	CertFile string
+	KeyFile  string
+
+	// CertCacheDir is where the self-signed dev certificate is cached
+	// between runs. Defaults to os.TempDir() when empty.
+	CertCacheDir string
+
+	// HSTS adds a Strict-Transport-Security header to every response.
+	HSTS bool
+
+	// ACME enables automatic Let's Encrypt certificates via autocert. When
+	// ACME.Domains is non-empty, it takes precedence over CertFile/KeyFile
+	// and the self-signed dev cert.
+	ACME ACMEConfig
+}
+
+// ACMEConfig configures golang.org/x/crypto/acme/autocert.
+type ACMEConfig struct {
+	// Domains are the hostnames certificates may be issued for.
+	Domains []string
+
+	// Email is passed to the ACME CA for expiry/revocation notices.
+	Email string
+
+	// CacheDir stores issued certificates between runs. Defaults to
+	// "certs" when empty.
+	CacheDir string
+}
+
+// BrowseConfig controls directory-listing (autoindex) behavior.
+type BrowseConfig struct {
+	// Enabled turns on directory listings.
+	Enabled bool
+
+	// ShowHidden includes dotfiles in the listing. Hidden by default.
+	ShowHidden bool
+
+	// Template overrides the default listing template. It is executed
+	// with a browseListing value.
+	Template *template.Template
 }
 
 // DefaultConfig returns a default configuration
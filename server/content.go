@@ -0,0 +1,103 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// precompressedSuffixes maps a content encoding to the file suffix the
+// server looks for a sibling file under.
+var precompressedSuffixes = map[string]string{
+	"br":   ".br",
+	"gzip": ".gz",
+}
+
+// serveContent serves file, honoring ETag/Last-Modified conditional GETs and
+// Range requests via http.ServeContent, and substituting a precompressed
+// sibling file when the client's Accept-Encoding allows it. name is the
+// original (uncompressed) fs.FS path, used for content-type sniffing.
+func (s *Server) serveContent(w http.ResponseWriter, r *http.Request, name string, file fs.File, info fs.FileInfo) {
+	servedFile := file
+	servedInfo := info
+
+	if encoding, variantFile, variantInfo, ok := s.openPrecompressedVariant(name, r); ok {
+		defer variantFile.Close()
+		servedFile = variantFile
+		servedInfo = variantInfo
+		w.Header().Set("Content-Encoding", encoding)
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	w.Header().Set("ETag", s.computeETag(servedInfo, servedFile))
+
+	if rs, ok := servedFile.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, name, servedInfo.ModTime(), rs)
+		return
+	}
+
+	// The underlying fs.File isn't seekable (e.g. a zip entry), so Range and
+	// conditional requests aren't supported - just copy the body.
+	if _, err := io.Copy(w, servedFile); err != nil {
+		s.logger.Errorf("Error serving file %s: %v", name, err)
+	}
+}
+
+// openPrecompressedVariant looks for a precompressed sibling of name (e.g.
+// "about.html.br") matching one of Config.PrecompressedEncodings that the
+// client's Accept-Encoding header allows, in preference order.
+func (s *Server) openPrecompressedVariant(name string, r *http.Request) (string, fs.File, fs.FileInfo, bool) {
+	acceptEncoding := r.Header.Get("Accept-Encoding")
+
+	for _, encoding := range s.config.PrecompressedEncodings {
+		suffix, known := precompressedSuffixes[encoding]
+		if !known || !strings.Contains(acceptEncoding, encoding) {
+			continue
+		}
+
+		file, err := s.fsys.Open(name + suffix)
+		if err != nil {
+			continue
+		}
+		info, err := file.Stat()
+		if err != nil || info.IsDir() {
+			file.Close()
+			continue
+		}
+		return encoding, file, info, true
+	}
+
+	return "", nil, nil, false
+}
+
+// computeETag derives an ETag for file. With Config.StrongETag it hashes the
+// content (only possible when file is seekable, so the read can be rewound
+// before the body is served); otherwise it uses a tag built from size+mtime.
+// fs.FS backends like embed.FS always report a zero ModTime, which would
+// otherwise collapse the size+mtime tag to a function of size alone and collide
+// across same-size files - when mtime is zero, hash the content instead
+// (falling back to the file name when it isn't seekable either).
+func (s *Server) computeETag(info fs.FileInfo, file fs.File) string {
+	modTime := info.ModTime()
+
+	if s.config.StrongETag || modTime.IsZero() {
+		if rs, ok := file.(io.ReadSeeker); ok {
+			h := sha256.New()
+			if _, err := io.Copy(h, rs); err == nil {
+				rs.Seek(0, io.SeekStart)
+				return fmt.Sprintf(`"%s"`, hex.EncodeToString(h.Sum(nil))[:16])
+			}
+			rs.Seek(0, io.SeekStart)
+		}
+
+		if modTime.IsZero() {
+			return fmt.Sprintf(`"%x-%x"`, info.Name(), info.Size())
+		}
+	}
+
+	return fmt.Sprintf(`"%x-%x"`, modTime.UnixNano(), info.Size())
+}
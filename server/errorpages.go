@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	pathpkg "path"
+)
+
+// ErrorPagesConfig controls custom error pages, modeled on the gitlab-workhorse
+// staticpages approach: a static file in Dir is served whenever a response
+// would otherwise come back as a 4xx/5xx.
+type ErrorPagesConfig struct {
+	// Enabled turns on error-page lookup.
+	Enabled bool
+
+	// Dir is the fs.FS path to look for error pages in, relative to
+	// Config.FS (or Config.RootDir when FS is unset). Defaults to the FS
+	// root when empty.
+	Dir string
+}
+
+// lookupErrorPage looks for a status-specific error page (e.g. 404.html),
+// falling back to a generic error.html. It returns the page content and
+// whether a match was found. Like the rest of the request path, this reads
+// through s.fsys so it works with Config.FS-backed servers, not just disk.
+func (s *Server) lookupErrorPage(status int) ([]byte, bool) {
+	dir := s.config.ErrorPages.Dir
+
+	candidates := []string{
+		pathpkg.Join(dir, fmt.Sprintf("%d.html", status)),
+		pathpkg.Join(dir, "error.html"),
+	}
+
+	for _, candidate := range candidates {
+		content, err := fs.ReadFile(s.fsys, candidate)
+		if err == nil {
+			return content, true
+		}
+	}
+
+	return nil, false
+}
+
+// serveErrorPage writes a looked-up error page with the correct status code
+// and a no-store Cache-Control header, so browsers and intermediaries never
+// cache an error response.
+func (s *Server) serveErrorPage(w http.ResponseWriter, status int, content []byte) {
+	w.Header().Set("Content-Type", "text/html")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	w.Write(content)
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestErrorPagesServesStatusSpecificPage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-errorpages-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = os.WriteFile(filepath.Join(tempDir, "404.html"), []byte("<html><body>Not Found</body></html>"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create 404.html: %v", err)
+	}
+
+	config := Config{
+		RootDir:    tempDir,
+		ErrorPages: ErrorPagesConfig{Enabled: true},
+	}
+	srv := NewServerWithConfig(config)
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+	if cacheControl := resp.Header.Get("Cache-Control"); cacheControl != "no-store" {
+		t.Errorf("Expected Cache-Control: no-store, got %q", cacheControl)
+	}
+	if !contains(w.Body.String(), "Not Found") {
+		t.Errorf("Expected body to contain custom 404 page, got: %s", w.Body.String())
+	}
+}
+
+func TestErrorPagesFallsBackToGenericPage(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-errorpages-generic-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = os.WriteFile(filepath.Join(tempDir, "error.html"), []byte("<html><body>Something went wrong</body></html>"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create error.html: %v", err)
+	}
+
+	config := Config{
+		RootDir:    tempDir,
+		ErrorPages: ErrorPagesConfig{Enabled: true},
+	}
+	srv := NewServerWithConfig(config)
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if !contains(w.Body.String(), "Something went wrong") {
+		t.Errorf("Expected body to contain generic error page, got: %s", w.Body.String())
+	}
+}
+
+func TestErrorPagesServesStatusSpecificPageFromFS(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-errorpages-fs-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	zipPath := filepath.Join(tempDir, "site.zip")
+	if err := writeTestZip(zipPath, map[string]string{
+		"404.html": "<html><body>Zipped Not Found</body></html>",
+	}); err != nil {
+		t.Fatalf("Failed to write test zip: %v", err)
+	}
+
+	zipFS, err := OpenZipFS(zipPath)
+	if err != nil {
+		t.Fatalf("OpenZipFS failed: %v", err)
+	}
+	defer zipFS.Close()
+
+	// RootDir is deliberately left unset here: Config.FS is the only
+	// filesystem the server should consult, for error pages as much as
+	// for everything else on the request path.
+	config := Config{
+		FS:         zipFS,
+		ErrorPages: ErrorPagesConfig{Enabled: true},
+	}
+	srv := NewServerWithConfig(config)
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", resp.StatusCode)
+	}
+	if !contains(w.Body.String(), "Zipped Not Found") {
+		t.Errorf("Expected body to contain error page served through Config.FS, got: %s", w.Body.String())
+	}
+}
+
+func TestErrorPagesFallsBackToNotFoundHandler(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-errorpages-fallback-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		RootDir:    tempDir,
+		ErrorPages: ErrorPagesConfig{Enabled: true},
+		NotFoundHandler: func(path string) []byte {
+			return []byte("Custom 404: " + path + " not found")
+		},
+	}
+	srv := NewServerWithConfig(config)
+
+	req := httptest.NewRequest("GET", "/missing", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if !contains(w.Body.String(), "Custom 404: /missing not found") {
+		t.Errorf("Expected body to fall back to NotFoundHandler, got: %s", w.Body.String())
+	}
+}
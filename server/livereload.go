@@ -0,0 +1,213 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// livereloadPath is the SSE endpoint browsers connect to for reload signals.
+const livereloadPath = "/_scribe/livereload"
+
+// livereloadScript is injected before </body> on every HTML response when
+// DevMode is enabled.
+const livereloadScript = `<script>(function(){var es=new EventSource("` + livereloadPath + `");es.onmessage=function(){location.reload();};})();</script>`
+
+const defaultReloadDebounce = 100 * time.Millisecond
+
+// reloadHub fans out reload signals to every connected SSE client.
+type reloadHub struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+func newReloadHub() *reloadHub {
+	return &reloadHub{
+		clients: make(map[chan struct{}]struct{}),
+	}
+}
+
+func (h *reloadHub) register() chan struct{} {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	h.clients[ch] = struct{}{}
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *reloadHub) unregister(ch chan struct{}) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	h.mu.Unlock()
+}
+
+func (h *reloadHub) broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// Client already has a pending reload queued.
+		}
+	}
+}
+
+// NewDevServer creates a server that watches rootDir for changes and pushes
+// live-reload signals to connected browsers over Server-Sent Events. onChange
+// is called before each reload signal so scribe can regenerate the site; it
+// may be nil.
+func NewDevServer(rootDir string, onChange func() error) (*Server, error) {
+	config := Config{
+		RootDir:          rootDir,
+		AddHtmlExtension: true,
+		EnableLogging:    true,
+		DevMode:          true,
+		OnChange:         onChange,
+	}
+
+	srv := NewServerWithConfig(config)
+	if err := srv.startWatching(); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// startWatching sets up the filesystem watcher and reload hub for a dev
+// server. It is a no-op if DevMode is disabled.
+func (s *Server) startWatching() error {
+	if !s.config.DevMode {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("livereload: failed to create watcher: %w", err)
+	}
+
+	if err := addWatchRecursive(watcher, s.config.RootDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("livereload: failed to watch %s: %w", s.config.RootDir, err)
+	}
+
+	s.reloadHub = newReloadHub()
+	s.watcher = watcher
+
+	debounce := s.config.ReloadDebounce
+	if debounce <= 0 {
+		debounce = defaultReloadDebounce
+	}
+
+	go s.watchLoop(debounce)
+	return nil
+}
+
+// addWatchRecursive adds every directory under root to the watcher.
+func addWatchRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// watchLoop debounces filesystem events, runs the rebuild hook, and
+// broadcasts a reload signal to connected clients.
+func (s *Server) watchLoop(debounce time.Duration) {
+	var timer *time.Timer
+
+	for {
+		select {
+		case event, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			s.logger.Debugf("Detected change: %s", event)
+
+			if timer == nil {
+				timer = time.AfterFunc(debounce, s.onDebouncedChange)
+			} else {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			s.logger.Errorf("Watcher error: %v", err)
+		}
+	}
+}
+
+// onDebouncedChange runs the rebuild hook (if any) and notifies browsers.
+func (s *Server) onDebouncedChange() {
+	if s.config.OnChange != nil {
+		if err := s.config.OnChange(); err != nil {
+			s.logger.Errorf("OnChange failed: %v", err)
+			return
+		}
+	}
+	s.reloadHub.broadcast()
+}
+
+// handleLiveReload serves the SSE endpoint browsers subscribe to for reload
+// notifications.
+func (s *Server) handleLiveReload(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ch := s.reloadHub.register()
+	defer s.reloadHub.unregister(ch)
+
+	for {
+		select {
+		case <-ch:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// injectLiveReloadScript inserts the livereload script before the closing
+// </body> tag, appending it to the end if no </body> tag is present.
+func injectLiveReloadScript(body []byte) []byte {
+	const marker = "</body>"
+	idx := lastIndex(body, marker)
+	if idx == -1 {
+		return append(body, []byte(livereloadScript)...)
+	}
+	out := make([]byte, 0, len(body)+len(livereloadScript))
+	out = append(out, body[:idx]...)
+	out = append(out, []byte(livereloadScript)...)
+	out = append(out, body[idx:]...)
+	return out
+}
+
+// lastIndex returns the index of the last occurrence of substr in s, or -1.
+func lastIndex(s []byte, substr string) int {
+	for i := len(s) - len(substr); i >= 0; i-- {
+		if string(s[i:i+len(substr)]) == substr {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDevServerInjectsLiveReloadScript(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-dev-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = os.WriteFile(filepath.Join(tempDir, "index.html"), []byte("<html><body>Hi</body></html>"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create index.html: %v", err)
+	}
+
+	srv, err := NewDevServer(tempDir, nil)
+	if err != nil {
+		t.Fatalf("NewDevServer failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	body := w.Body.String()
+	if !contains(body, livereloadScript) {
+		t.Errorf("Expected body to contain live-reload script, got: %s", body)
+	}
+}
+
+func TestDanglingDevModeWithoutNewDevServerIsNoop(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-dev-dangling-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = os.WriteFile(filepath.Join(tempDir, "index.html"), []byte("<html><body>Hi</body></html>"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to create index.html: %v", err)
+	}
+
+	// DevMode set directly via NewServerWithConfig, bypassing NewDevServer,
+	// leaves reloadHub nil. This must not panic.
+	srv := NewServerWithConfig(Config{RootDir: tempDir, DevMode: true})
+
+	req := httptest.NewRequest("GET", livereloadPath, nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Result().StatusCode == http.StatusOK {
+		t.Errorf("Expected livereload endpoint to not be served without NewDevServer, got 200")
+	}
+
+	req2 := httptest.NewRequest("GET", "/", nil)
+	w2 := httptest.NewRecorder()
+	srv.ServeHTTP(w2, req2)
+
+	if contains(w2.Body.String(), livereloadScript) {
+		t.Error("Expected no live-reload script injected without a reload hub")
+	}
+}
+
+func TestDevServerBroadcastsOnFileChange(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-dev-watch-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	indexPath := filepath.Join(tempDir, "index.html")
+	if err := os.WriteFile(indexPath, []byte("<html></html>"), 0644); err != nil {
+		t.Fatalf("Failed to create index.html: %v", err)
+	}
+
+	onChangeCalled := make(chan struct{}, 1)
+	srv, err := NewDevServer(tempDir, func() error {
+		onChangeCalled <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewDevServer failed: %v", err)
+	}
+	defer srv.watcher.Close()
+
+	if err := os.WriteFile(indexPath, []byte("<html>changed</html>"), 0644); err != nil {
+		t.Fatalf("Failed to modify index.html: %v", err)
+	}
+
+	select {
+	case <-onChangeCalled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected OnChange to be called after a file change")
+	}
+}
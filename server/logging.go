@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Logger receives structured information about requests and internal
+// server events. RequestStart/RequestEnd bracket each request; Debugf and
+// Errorf carry internal diagnostics (file lookups, watcher errors, etc.)
+// that used to go straight to log.Printf.
+type Logger interface {
+	RequestStart(r *http.Request)
+	RequestEnd(r *http.Request, status int, bytes int64, duration time.Duration)
+	Debugf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// noopLogger discards everything. Used when EnableLogging is false and no
+// Logger is set.
+type noopLogger struct{}
+
+func (noopLogger) RequestStart(*http.Request)                          {}
+func (noopLogger) RequestEnd(*http.Request, int, int64, time.Duration) {}
+func (noopLogger) Debugf(string, ...interface{})                       {}
+func (noopLogger) Errorf(string, ...interface{})                       {}
+
+// CommonLogFormatLogger writes one Apache Combined Log Format line per
+// request to out.
+type CommonLogFormatLogger struct {
+	out io.Writer
+}
+
+// NewCommonLogFormatLogger returns a Logger that writes Combined Log Format
+// lines to out. This is the default when EnableLogging is true.
+func NewCommonLogFormatLogger(out io.Writer) *CommonLogFormatLogger {
+	return &CommonLogFormatLogger{out: out}
+}
+
+func (l *CommonLogFormatLogger) RequestStart(*http.Request) {}
+
+func (l *CommonLogFormatLogger) RequestEnd(r *http.Request, status int, bytes int64, duration time.Duration) {
+	fmt.Fprintf(l.out, "%s - - [%s] \"%s %s %s\" %d %d \"%s\" \"%s\"\n",
+		r.RemoteAddr,
+		time.Now().Format("02/Jan/2006:15:04:05 -0700"),
+		r.Method, r.URL.RequestURI(), r.Proto,
+		status, bytes,
+		r.Referer(), r.UserAgent(),
+	)
+}
+
+func (l *CommonLogFormatLogger) Debugf(format string, args ...interface{}) {
+	fmt.Fprintf(l.out, "[SERVER] "+format+"\n", args...)
+}
+
+func (l *CommonLogFormatLogger) Errorf(format string, args ...interface{}) {
+	fmt.Fprintf(l.out, "[SERVER] ERROR: "+format+"\n", args...)
+}
+
+// JSONLogger writes one JSON object per line, suitable for shipping to log
+// aggregators.
+type JSONLogger struct {
+	out io.Writer
+}
+
+// NewJSONLogger returns a Logger that writes JSON-lines records to out.
+func NewJSONLogger(out io.Writer) *JSONLogger {
+	return &JSONLogger{out: out}
+}
+
+func (l *JSONLogger) RequestStart(*http.Request) {}
+
+func (l *JSONLogger) RequestEnd(r *http.Request, status int, bytes int64, duration time.Duration) {
+	l.writeLine(map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"status":      status,
+		"bytes":       bytes,
+		"remote_addr": r.RemoteAddr,
+		"user_agent":  r.UserAgent(),
+		"referer":     r.Referer(),
+		"duration_ms": duration.Milliseconds(),
+	})
+}
+
+func (l *JSONLogger) Debugf(format string, args ...interface{}) {
+	l.writeLine(map[string]interface{}{"level": "debug", "message": fmt.Sprintf(format, args...)})
+}
+
+func (l *JSONLogger) Errorf(format string, args ...interface{}) {
+	l.writeLine(map[string]interface{}{"level": "error", "message": fmt.Sprintf(format, args...)})
+}
+
+func (l *JSONLogger) writeLine(record map[string]interface{}) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	l.out.Write(append(b, '\n'))
+}
+
+// slogLogger adapts a log/slog.Handler to the Logger interface, for users on
+// Go 1.21+ who want to plug into their existing observability stack.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a Logger backed by the given slog.Handler.
+func NewSlogLogger(handler slog.Handler) Logger {
+	return &slogLogger{logger: slog.New(handler)}
+}
+
+func (l *slogLogger) RequestStart(*http.Request) {}
+
+func (l *slogLogger) RequestEnd(r *http.Request, status int, bytes int64, duration time.Duration) {
+	l.logger.Info("request",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", status,
+		"bytes", bytes,
+		"remote_addr", r.RemoteAddr,
+		"user_agent", r.UserAgent(),
+		"referer", r.Referer(),
+		"duration", duration,
+	)
+}
+
+func (l *slogLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *slogLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommonLogFormatLoggerWritesOneLinePerRequest(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-logging-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := os.WriteFile(filepath.Join(tempDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatalf("Failed to create index.html: %v", err)
+	}
+
+	var buf bytes.Buffer
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		Logger:  NewCommonLogFormatLogger(&buf),
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	if w.Result().StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Result().StatusCode)
+	}
+
+	logLine := buf.String()
+	if !strings.Contains(logLine, `"GET / HTTP/1.1"`) {
+		t.Errorf("Expected Combined Log Format request line, got: %s", logLine)
+	}
+	if !strings.Contains(logLine, " 200 ") {
+		t.Errorf("Expected status 200 in log line, got: %s", logLine)
+	}
+}
+
+func TestJSONLoggerWritesStructuredRecord(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-jsonlog-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := os.WriteFile(filepath.Join(tempDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatalf("Failed to create index.html: %v", err)
+	}
+
+	var buf bytes.Buffer
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		Logger:  NewJSONLogger(&buf),
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	logLine := buf.String()
+	if !strings.Contains(logLine, `"method":"GET"`) {
+		t.Errorf("Expected JSON log line with method field, got: %s", logLine)
+	}
+	if !strings.Contains(logLine, `"status":200`) {
+		t.Errorf("Expected JSON log line with status 200, got: %s", logLine)
+	}
+}
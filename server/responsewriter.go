@@ -0,0 +1,94 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// bufferedResponseWriter buffers a response so its status code and body can
+// be inspected (and replaced) before anything reaches the real
+// http.ResponseWriter. This is what lets the server swap in a custom error
+// page after a handler has already decided to return a 4xx/5xx.
+type bufferedResponseWriter struct {
+	header      http.Header
+	status      int
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.status = status
+	b.wroteHeader = true
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if !b.wroteHeader {
+		b.WriteHeader(http.StatusOK)
+	}
+	return b.body.Write(p)
+}
+
+// flush copies the buffered status, headers, and body onto the real
+// http.ResponseWriter.
+func (b *bufferedResponseWriter) flush(w http.ResponseWriter) {
+	dst := w.Header()
+	for key, values := range b.header {
+		dst[key] = values
+	}
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
+
+// statusRecorder wraps a real http.ResponseWriter and records the status
+// code and byte count of what was actually written, for request logging.
+// Unlike bufferedResponseWriter it writes straight through.
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func newStatusRecorder(w http.ResponseWriter) *statusRecorder {
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	if !s.wroteHeader {
+		s.WriteHeader(http.StatusOK)
+	}
+	n, err := s.ResponseWriter.Write(p)
+	s.bytes += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher by delegating to the wrapped writer when it
+// supports flushing, so streaming responses like the live-reload SSE
+// endpoint keep working when wrapped.
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
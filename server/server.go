@@ -2,97 +2,165 @@ package server
 
 import (
 	"io"
-	"log"
+	"io/fs"
 	"net/http"
 	"os"
-	"path/filepath"
+	pathpkg "path"
 	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 // Server is a custom HTTP server that doesn't redirect paths without trailing slashes
 type Server struct {
 	config Config
-	logger *log.Logger
+	logger Logger
+	fsys   fs.FS
+
+	// reloadHub and watcher are only set when config.DevMode is enabled.
+	reloadHub *reloadHub
+	watcher   *fsnotify.Watcher
 }
 
 // NewServer creates a new server that serves files from the given directory
 func NewServer(rootDir string) *Server {
-	return &Server{
-		config: Config{
-			RootDir:          rootDir,
-			AddHtmlExtension: true,
-			EnableLogging:    true,
-		},
-		logger: log.New(os.Stdout, "[SERVER] ", log.LstdFlags),
-	}
+	return NewServerWithConfig(Config{
+		RootDir:          rootDir,
+		AddHtmlExtension: true,
+		EnableLogging:    true,
+	})
 }
 
-// NewServerWithConfig creates a new server with the provided configuration
+// NewServerWithConfig creates a new server with the provided configuration.
+// If config.FS is set, it is used as the filesystem to serve from and
+// config.RootDir is ignored; otherwise files are served from config.RootDir
+// on disk.
 func NewServerWithConfig(config Config) *Server {
-	var logger *log.Logger
-	if config.EnableLogging {
-		logger = log.New(os.Stdout, "[SERVER] ", log.LstdFlags)
-	} else {
-		logger = log.New(io.Discard, "", 0)
+	logger := config.Logger
+	if logger == nil {
+		if config.EnableLogging {
+			logger = NewCommonLogFormatLogger(os.Stdout)
+		} else {
+			logger = noopLogger{}
+		}
+	}
+
+	fsys := config.FS
+	if fsys == nil {
+		fsys = os.DirFS(config.RootDir)
 	}
-	
+
 	return &Server{
 		config: config,
 		logger: logger,
+		fsys:   fsys,
 	}
 }
 
 // ServeHTTP implements the http.Handler interface
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.logger.RequestStart(r)
+	start := time.Now()
+	rec := newStatusRecorder(w)
+	defer func() {
+		s.logger.RequestEnd(r, rec.status, rec.bytes, time.Since(start))
+	}()
+
+	// Serve the live-reload SSE stream before any path cleaning/rewriting.
+	// reloadHub is only set once startWatching has run (NewDevServer does
+	// this); a dangling DevMode flag set via NewServerWithConfig is a no-op
+	// here instead of a nil-pointer panic in reloadHub.register().
+	if s.config.DevMode && s.reloadHub != nil && r.URL.Path == livereloadPath {
+		s.handleLiveReload(rec, r)
+		return
+	}
+
+	// Without error pages there's nothing to intercept, so write straight
+	// through to the status-recording ResponseWriter as before.
+	if !s.config.ErrorPages.Enabled {
+		s.serve(rec, r)
+		return
+	}
+
+	// With error pages enabled, buffer the response so a 4xx/5xx status can
+	// be swapped for a matching static page before anything is flushed.
+	bw := newBufferedResponseWriter()
+	s.serve(bw, r)
+
+	if bw.status >= 400 {
+		if content, ok := s.lookupErrorPage(bw.status); ok {
+			s.serveErrorPage(rec, bw.status, content)
+			return
+		}
+	}
+
+	bw.flush(rec)
+}
+
+// serve contains the core routing logic shared by direct and buffered
+// responses.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
 	// Clean the path to prevent directory traversal attacks
-	path := filepath.Clean(r.URL.Path)
-	
-	// If it's the root path, serve index.html
-	if path == "/" {
-		path = "/index.html"
-	}
-	
-	s.logger.Printf("Handling request for: %s", path)
-	
+	path := pathpkg.Clean(r.URL.Path)
+	isRoot := path == "/"
+
+	s.logger.Debugf("Handling request for: %s", path)
+
 	// Remove trailing slash if it exists (except for root path)
 	// This is the key to preventing redirects to trailing slashes
 	if len(path) > 1 && strings.HasSuffix(path, "/") {
 		path = path[:len(path)-1]
 	}
-	
+
 	// Try different file possibilities in this order:
 	// 1. Exact path match
 	// 2. Adding .html extension if no extension exists (if enabled)
-	// 3. Look for index.html in directory (only if path originally had trailing slash)
-	
-	// Construct the full file path
-	fullPath := filepath.Join(s.config.RootDir, path)
-	
-	// First try: exact path match
-	if s.tryServeFile(w, r, fullPath) {
-		return
+	// 3. Look for index.html in directory (only if path originally had trailing slash),
+	//    falling back to a directory listing when browsing is enabled.
+
+	// fs.FS names are slash-separated and never start with "/". The root
+	// path maps to the FS root (""), which tryServeFile treats as a directory.
+	var name string
+	if !isRoot {
+		name = strings.TrimPrefix(path, "/")
 	}
-	
-	// Second try: add .html extension if no extension exists and option is enabled
-	if s.config.AddHtmlExtension && filepath.Ext(path) == "" {
-		htmlPath := fullPath + ".html"
-		if s.tryServeFile(w, r, htmlPath) {
+
+	if isRoot {
+		// First try: root serves index.html directly
+		if s.tryServeFile(w, r, "index.html") {
+			return
+		}
+	} else {
+		// First try: exact path match
+		if s.tryServeFile(w, r, name) {
 			return
 		}
+
+		// Second try: add .html extension if no extension exists and option is enabled
+		if s.config.AddHtmlExtension && pathpkg.Ext(path) == "" {
+			if s.tryServeFile(w, r, name+".html") {
+				return
+			}
+		}
 	}
-	
-	// Third try: check for index.html in directory
+
+	// Third try: check for index.html in directory, falling back to a
+	// directory listing when browsing is enabled.
 	// Only do this for paths that originally had a trailing slash
-	if strings.HasSuffix(r.URL.Path, "/") {
-		indexPath := filepath.Join(fullPath, "index.html")
-		if s.tryServeFile(w, r, indexPath) {
+	if isRoot || strings.HasSuffix(r.URL.Path, "/") {
+		indexName := pathpkg.Join(name, "index.html")
+		if s.tryServeFile(w, r, indexName) {
+			return
+		}
+		if s.config.Browse.Enabled && s.serveBrowse(w, r, name) {
 			return
 		}
 	}
-	
+
 	// If all attempts fail, handle 404
-	s.logger.Printf("Not found: %s", path)
-	
+	s.logger.Debugf("Not found: %s", path)
+
 	// Use custom 404 handler if provided
 	if s.config.NotFoundHandler != nil {
 		content := s.config.NotFoundHandler(path)
@@ -100,42 +168,58 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Write(content)
 		return
 	}
-	
+
 	// Otherwise use standard 404
 	http.NotFound(w, r)
 }
 
-// tryServeFile attempts to serve the file at the given path
+// tryServeFile attempts to serve the file with the given fs.FS name.
 // Returns true if successful, false otherwise
-func (s *Server) tryServeFile(w http.ResponseWriter, r *http.Request, fullPath string) bool {
-	info, err := os.Stat(fullPath)
-	
-	// If file doesn't exist or is a directory, return false
-	if err != nil || info.IsDir() {
-		return false
+func (s *Server) tryServeFile(w http.ResponseWriter, r *http.Request, name string) bool {
+	if name == "" {
+		name = "."
 	}
-	
-	// Open the file
-	file, err := os.Open(fullPath)
+
+	file, err := s.fsys.Open(name)
 	if err != nil {
-		s.logger.Printf("Error opening file %s: %v", fullPath, err)
 		return false
 	}
 	defer file.Close()
-	
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
 	// Set content type
-	contentType := getContentType(filepath.Ext(fullPath))
+	contentType := getContentType(pathpkg.Ext(name))
 	if contentType != "" {
 		w.Header().Set("Content-Type", contentType)
 	}
-	
-	// Copy file content to response writer
-	s.logger.Printf("Serving file: %s", fullPath)
-	_, err = io.Copy(w, file)
-	if err != nil {
-		s.logger.Printf("Error serving file %s: %v", fullPath, err)
+
+	s.logger.Debugf("Serving file: %s", name)
+
+	// In dev mode, HTML responses get the live-reload script injected, which
+	// means the body has to be buffered instead of streamed straight through.
+	// Skip this when reloadHub hasn't been set up (DevMode without
+	// NewDevServer) since there would be nothing for the script to connect to.
+	if s.config.DevMode && s.reloadHub != nil && contentType == "text/html" {
+		body, err := io.ReadAll(file)
+		if err != nil {
+			s.logger.Errorf("Error reading file %s: %v", name, err)
+			return true
+		}
+		_, err = w.Write(injectLiveReloadScript(body))
+		if err != nil {
+			s.logger.Errorf("Error serving file %s: %v", name, err)
+		}
+		return true
 	}
-	
+
+	// Serve with ETag/Last-Modified conditional GET support, Range requests,
+	// and precompressed variants when configured.
+	s.serveContent(w, r, name, file, info)
+
 	return true
 }
 
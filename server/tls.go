@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// ListenAndServe starts an HTTP server for handler on addr and blocks until
+// it exits, either because it failed to start or because it was gracefully
+// shut down after a SIGINT/SIGTERM.
+func ListenAndServe(addr string, handler http.Handler) error {
+	httpServer := &http.Server{Addr: addr, Handler: handler}
+	return serveWithGracefulShutdown(httpServer, httpServer.ListenAndServe)
+}
+
+// ListenAndServeTLS starts an HTTPS server for s on addr and blocks until it
+// exits. The certificate comes from, in order of precedence: config.TLS.ACME
+// (automatic Let's Encrypt via autocert), config.TLS.CertFile/KeyFile, or a
+// self-signed certificate for localhost generated and cached for dev use.
+// An HTTP->HTTPS redirect listener is also started on port 80 of addr's host
+// (see httpRedirectAddr) - for ACME it doubles as the http-01 challenge
+// responder, and for the CertFile/self-signed paths it just redirects.
+func (s *Server) ListenAndServeTLS(addr string) error {
+	tlsConfig, redirectHandler, err := s.buildTLSConfig(addr)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Addr:      addr,
+		Handler:   s.withHSTS(s),
+		TLSConfig: tlsConfig,
+	}
+
+	if redirectHandler != nil {
+		go func() {
+			if err := http.ListenAndServe(httpRedirectAddr(addr), redirectHandler); err != nil {
+				s.logger.Errorf("HTTP redirect listener on %s failed: %v", httpRedirectAddr(addr), err)
+			}
+		}()
+	}
+
+	return serveWithGracefulShutdown(httpServer, func() error {
+		return httpServer.ListenAndServeTLS("", "")
+	})
+}
+
+// withHSTS adds a Strict-Transport-Security header to every response when
+// config.TLS.HSTS is enabled.
+func (s *Server) withHSTS(next http.Handler) http.Handler {
+	if !s.config.TLS.HSTS {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// buildTLSConfig resolves the TLS certificate source and an HTTP->HTTPS
+// redirect handler, pointing at addr's port, for the plain-HTTP listener. Every
+// certificate source gets a redirect handler, not just ACME - otherwise
+// plain http://host requests just hang against a port nothing is serving.
+func (s *Server) buildTLSConfig(addr string) (*tls.Config, http.Handler, error) {
+	redirect := redirectToHTTPSHandler(tlsPort(addr))
+
+	if len(s.config.TLS.ACME.Domains) > 0 {
+		cacheDir := s.config.TLS.ACME.CacheDir
+		if cacheDir == "" {
+			cacheDir = "certs"
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(s.config.TLS.ACME.Domains...),
+			Cache:      autocert.DirCache(cacheDir),
+			Email:      s.config.TLS.ACME.Email,
+		}
+		// manager.HTTPHandler answers http-01 challenges itself and falls
+		// back to redirect for everything else.
+		return manager.TLSConfig(), manager.HTTPHandler(redirect), nil
+	}
+
+	if s.config.TLS.CertFile != "" && s.config.TLS.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.config.TLS.CertFile, s.config.TLS.KeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &tls.Config{Certificates: []tls.Certificate{cert}}, redirect, nil
+	}
+
+	cert, err := s.devSelfSignedCert()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, redirect, nil
+}
+
+// redirectToHTTPSHandler returns a handler that redirects every request to
+// its HTTPS equivalent on httpsPort, preserving host, path, and query.
+// httpsPort is omitted from the target when it's the default 443.
+func redirectToHTTPSHandler(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+}
+
+// tlsPort extracts the port scribe's HTTPS listener is bound to from addr,
+// for use in HTTP->HTTPS redirect targets. Defaults to 443 if addr has no
+// parseable port.
+func tlsPort(addr string) string {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "443"
+	}
+	return port
+}
+
+// devSelfSignedCert loads a cached self-signed localhost certificate from
+// config.TLS.CertCacheDir, generating and caching one if it doesn't exist yet
+// or has expired.
+func (s *Server) devSelfSignedCert() (tls.Certificate, error) {
+	cacheDir := s.config.TLS.CertCacheDir
+	if cacheDir == "" {
+		cacheDir = os.TempDir()
+	}
+	certPath := filepath.Join(cacheDir, "scribe-dev-cert.pem")
+	keyPath := filepath.Join(cacheDir, "scribe-dev-key.pem")
+
+	if cert, err := tls.LoadX509KeyPair(certPath, keyPath); err == nil {
+		if leaf, err := x509.ParseCertificate(cert.Certificate[0]); err == nil && time.Now().Before(leaf.NotAfter) {
+			return cert, nil
+		}
+	}
+
+	return generateSelfSignedCert(certPath, keyPath)
+}
+
+// generateSelfSignedCert creates a self-signed cert/key for localhost and
+// 127.0.0.1/::1, valid for one year, and writes both to disk for reuse.
+func generateSelfSignedCert(certPath, keyPath string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"scribe-server dev cert"}, CommonName: "localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// httpRedirectAddr derives the plain-HTTP address the redirect listener
+// binds to from the HTTPS listen address, replacing its port with 80.
+func httpRedirectAddr(tlsAddr string) string {
+	host, _, err := net.SplitHostPort(tlsAddr)
+	if err != nil {
+		return ":80"
+	}
+	return net.JoinHostPort(host, "80")
+}
+
+// serveWithGracefulShutdown runs serveFunc in the background and shuts
+// httpServer down cleanly on SIGINT/SIGTERM, so scribe can stop serving
+// without dropping in-flight requests.
+func serveWithGracefulShutdown(httpServer *http.Server, serveFunc func() error) error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- serveFunc() }()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	select {
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDevSelfSignedCertIsGeneratedAndCached(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-tls-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		TLS:     TLSConfig{CertCacheDir: tempDir},
+	})
+
+	cert1, err := srv.devSelfSignedCert()
+	if err != nil {
+		t.Fatalf("devSelfSignedCert failed: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert1.Certificate[0])
+	if err != nil {
+		t.Fatalf("Failed to parse generated certificate: %v", err)
+	}
+	if !contains(leaf.DNSNames[0], "localhost") {
+		t.Errorf("Expected certificate to cover localhost, got DNSNames: %v", leaf.DNSNames)
+	}
+	if leaf.NotAfter.Before(time.Now()) {
+		t.Error("Expected generated certificate to not be expired")
+	}
+
+	// A second call should load the cached cert rather than regenerating it.
+	certPath := filepath.Join(tempDir, "scribe-dev-cert.pem")
+	info1, err := os.Stat(certPath)
+	if err != nil {
+		t.Fatalf("Expected cert file to exist: %v", err)
+	}
+
+	cert2, err := srv.devSelfSignedCert()
+	if err != nil {
+		t.Fatalf("devSelfSignedCert (cached) failed: %v", err)
+	}
+	info2, _ := os.Stat(certPath)
+	if !info1.ModTime().Equal(info2.ModTime()) {
+		t.Error("Expected cached cert file to be reused, not regenerated")
+	}
+	if len(cert1.Certificate[0]) != len(cert2.Certificate[0]) {
+		t.Error("Expected cached certificate to match the originally generated one")
+	}
+}
+
+func TestBuildTLSConfigUsesProvidedCertFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-tls-custom-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	certPath := filepath.Join(tempDir, "cert.pem")
+	keyPath := filepath.Join(tempDir, "key.pem")
+	if _, err := generateSelfSignedCert(certPath, keyPath); err != nil {
+		t.Fatalf("Failed to generate test certificate: %v", err)
+	}
+
+	srv := NewServerWithConfig(Config{
+		RootDir: tempDir,
+		TLS:     TLSConfig{CertFile: certPath, KeyFile: keyPath},
+	})
+
+	tlsConfig, redirectHandler, err := srv.buildTLSConfig(":8443")
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %v", err)
+	}
+	if redirectHandler == nil {
+		t.Fatal("Expected an HTTP->HTTPS redirect handler even when using a provided cert")
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Fatalf("Expected exactly one certificate, got %d", len(tlsConfig.Certificates))
+	}
+
+	req := httptest.NewRequest("GET", "http://example.com/about?x=1", nil)
+	w := httptest.NewRecorder()
+	redirectHandler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusMovedPermanently {
+		t.Fatalf("Expected %d, got %d", http.StatusMovedPermanently, resp.StatusCode)
+	}
+	if loc := resp.Header.Get("Location"); loc != "https://example.com:8443/about?x=1" {
+		t.Errorf("Expected redirect to https://example.com:8443/about?x=1, got %q", loc)
+	}
+}
+
+func TestHTTPRedirectAddr(t *testing.T) {
+	if got := httpRedirectAddr(":8443"); got != ":80" {
+		t.Errorf("Expected :80, got %s", got)
+	}
+	if got := httpRedirectAddr("example.com:8443"); got != "example.com:80" {
+		t.Errorf("Expected example.com:80, got %s", got)
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"archive/zip"
+	"embed"
+	"io/fs"
+	"os"
+)
+
+// NewEmbedFS returns the subtree of fsys rooted at dir as an fs.FS suitable
+// for Config.FS, so a scribe site can be compiled into a single binary via
+// go:embed. dir is typically the same directory passed to the embed
+// directive, e.g. "public".
+func NewEmbedFS(fsys embed.FS, dir string) (fs.FS, error) {
+	return fs.Sub(fsys, dir)
+}
+
+// ZipFS serves files lazily out of a .zip archive on disk, in the spirit of
+// gitlab-pages' zip-backed site serving. The underlying archive is opened
+// once; individual entries are only read as requests access them.
+type ZipFS struct {
+	*zip.Reader
+	file *os.File
+}
+
+// OpenZipFS opens the zip archive at zipPath and returns it as an fs.FS.
+// Callers are responsible for calling Close when the server is done with it.
+func OpenZipFS(zipPath string) (*ZipFS, error) {
+	file, err := os.Open(zipPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &ZipFS{Reader: reader, file: file}, nil
+}
+
+// Close closes the underlying zip archive file.
+func (z *ZipFS) Close() error {
+	return z.file.Close()
+}
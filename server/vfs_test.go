@@ -0,0 +1,90 @@
+package server
+
+import (
+	"archive/zip"
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+//go:embed testdata/embedsite
+var embeddedSite embed.FS
+
+func TestServerWithEmbedFS(t *testing.T) {
+	sub, err := NewEmbedFS(embeddedSite, "testdata/embedsite")
+	if err != nil {
+		t.Fatalf("NewEmbedFS failed: %v", err)
+	}
+
+	srv := NewServerWithConfig(Config{FS: sub, AddHtmlExtension: true, EnableLogging: false})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if !contains(w.Body.String(), "Embedded Index") {
+		t.Errorf("Expected body to contain embedded index content, got: %s", w.Body.String())
+	}
+}
+
+func TestServerWithZipFS(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scribe-server-zipfs-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	zipPath := filepath.Join(tempDir, "site.zip")
+	if err := writeTestZip(zipPath, map[string]string{
+		"index.html": "<html><body>Zipped Index</body></html>",
+	}); err != nil {
+		t.Fatalf("Failed to write test zip: %v", err)
+	}
+
+	zipFS, err := OpenZipFS(zipPath)
+	if err != nil {
+		t.Fatalf("OpenZipFS failed: %v", err)
+	}
+	defer zipFS.Close()
+
+	srv := NewServerWithConfig(Config{FS: zipFS, AddHtmlExtension: true, EnableLogging: false})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if !contains(w.Body.String(), "Zipped Index") {
+		t.Errorf("Expected body to contain zipped index content, got: %s", w.Body.String())
+	}
+}
+
+func writeTestZip(zipPath string, files map[string]string) error {
+	f, err := os.Create(zipPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}